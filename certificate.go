@@ -0,0 +1,69 @@
+package git
+
+/*
+#include <git2.h>
+*/
+import "C"
+import "unsafe"
+
+// CertificateKind indicates which kind of certificate a Certificate holds.
+type CertificateKind uint
+
+const (
+	CertificateNone CertificateKind = iota
+	CertificateX509
+	CertificateHostkey
+)
+
+// HostkeyCertificate holds the hashes of an SSH host key, as presented
+// during the SSH handshake. Only the hashes requested by the server's
+// negotiated hostkey-hash algorithm are populated; the rest are left zero.
+type HostkeyCertificate struct {
+	HashMD5    [16]byte
+	HashSHA1   [20]byte
+	HashSHA256 [32]byte
+}
+
+// Certificate represents the certificate presented by a remote server
+// during the TLS or SSH handshake, as passed to a CertificateCheckCallback.
+type Certificate struct {
+	Kind CertificateKind
+
+	// X509 holds the DER-encoded certificate when Kind is CertificateX509.
+	X509 []byte
+
+	// Hostkey holds the SSH host-key hashes when Kind is CertificateHostkey.
+	Hostkey HostkeyCertificate
+}
+
+func newCertificateFromC(ccert *C.git_cert) *Certificate {
+	if ccert == nil {
+		return nil
+	}
+
+	switch ccert.cert_type {
+	case C.GIT_CERT_X509:
+		cx509 := (*C.git_cert_x509)(unsafe.Pointer(ccert))
+		return &Certificate{
+			Kind: CertificateX509,
+			X509: C.GoBytes(cx509.data, C.int(cx509.len)),
+		}
+
+	case C.GIT_CERT_HOSTKEY_LIBSSH2:
+		chostkey := (*C.git_cert_hostkey)(unsafe.Pointer(ccert))
+		cert := &Certificate{Kind: CertificateHostkey}
+		if chostkey._type&C.GIT_CERT_SSH_MD5 != 0 {
+			copy(cert.Hostkey.HashMD5[:], C.GoBytes(unsafe.Pointer(&chostkey.hash_md5[0]), 16))
+		}
+		if chostkey._type&C.GIT_CERT_SSH_SHA1 != 0 {
+			copy(cert.Hostkey.HashSHA1[:], C.GoBytes(unsafe.Pointer(&chostkey.hash_sha1[0]), 20))
+		}
+		if chostkey._type&C.GIT_CERT_SSH_SHA256 != 0 {
+			copy(cert.Hostkey.HashSHA256[:], C.GoBytes(unsafe.Pointer(&chostkey.hash_sha256[0]), 32))
+		}
+		return cert
+
+	default:
+		return &Certificate{Kind: CertificateNone}
+	}
+}
@@ -20,9 +20,39 @@ type CloneOptions struct {
 	Bare                 bool
 	CheckoutBranch       string
 	RemoteCreateCallback RemoteCreateCallback
+
+	// IgnoreCertErrors disables certificate verification for the clone's
+	// transport entirely. Prefer FetchOptions.RemoteCallbacks.CertificateCheckCallback
+	// when only specific certificates need to be accepted.
+	IgnoreCertErrors bool
+
+	// Mirror sets up the clone's remote the way `git clone --mirror` does:
+	// a "+refs/*:refs/*" fetch refspec and remote.<name>.mirror=true in the
+	// resulting repository's config. It cannot be combined with a
+	// caller-supplied RemoteCreateCallback.
+	Mirror bool
+
+	// PostCloneCallback, if set, is called with the freshly cloned
+	// repository after git_clone succeeds but before Clone returns, so
+	// callers can configure additional refspecs, tag settings, or shallow
+	// parameters without racing a second connection to the remote.
+	PostCloneCallback func(*Repository) error
 }
 
+// Clone is the cgo-backed implementation underlying the default
+// RepositoryClient returned by the repository subpackage's NewClient; it
+// stays a free function, rather than delegating to that package, to avoid
+// an import cycle (repository imports git for its public types).
 func Clone(url string, path string, options *CloneOptions) (*Repository, error) {
+	if err := validateMirrorOptions(options); err != nil {
+		return nil, err
+	}
+	if options != nil && options.Mirror {
+		mirrorOptions := *options
+		mirrorOptions.RemoteCreateCallback = mirrorRemoteCreateCallback
+		options = &mirrorOptions
+	}
+
 	curl := C.CString(url)
 	defer C.free(unsafe.Pointer(curl))
 
@@ -50,7 +80,25 @@ func Clone(url string, path string, options *CloneOptions) (*Repository, error)
 		return nil, MakeGitError(ret)
 	}
 
-	return newRepositoryFromC(ptr), nil
+	repo := newRepositoryFromC(ptr)
+
+	if options.PostCloneCallback != nil {
+		if err := options.PostCloneCallback(repo); err != nil {
+			return nil, err
+		}
+	}
+
+	return repo, nil
+}
+
+// validateMirrorOptions rejects the one combination CloneOptions.Mirror
+// can't compose with: a caller-supplied RemoteCreateCallback, which the
+// mirror setup needs to install itself.
+func validateMirrorOptions(options *CloneOptions) error {
+	if options != nil && options.Mirror && options.RemoteCreateCallback != nil {
+		return errors.New("git: Mirror cannot be combined with a custom RemoteCreateCallback")
+	}
+	return nil
 }
 
 //export remoteCreateCallback
@@ -103,8 +151,9 @@ func populateCloneOptions(ptr *C.git_clone_options, opts *CloneOptions, errorTar
 		return nil
 	}
 	populateCheckoutOptions(&ptr.checkout_opts, opts.CheckoutOpts, errorTarget)
-	populateFetchOptions(&ptr.fetch_opts, opts.FetchOptions)
+	populateFetchOptions(&ptr.fetch_opts, opts.FetchOptions, errorTarget)
 	ptr.bare = cbool(opts.Bare)
+	ptr.ignore_cert_errors = cbool(opts.IgnoreCertErrors)
 
 	if opts.RemoteCreateCallback != nil {
 		data := &cloneCallbackData{
@@ -125,6 +174,7 @@ func freeCloneOptions(ptr *C.git_clone_options) {
 	}
 
 	freeCheckoutOptions(&ptr.checkout_opts)
+	freeFetchOptions(&ptr.fetch_opts)
 
 	if ptr.remote_cb_payload != nil {
 		pointerHandles.Untrack(ptr.remote_cb_payload)
@@ -0,0 +1,31 @@
+package git
+
+import "testing"
+
+func TestValidateMirrorOptions(t *testing.T) {
+	noop := func(repo *Repository, name, url string) (*Remote, ErrorCode) { return nil, ErrorCodeOK }
+
+	tests := []struct {
+		name    string
+		options *CloneOptions
+		wantErr bool
+	}{
+		{"nil options", nil, false},
+		{"neither set", &CloneOptions{}, false},
+		{"mirror only", &CloneOptions{Mirror: true}, false},
+		{"callback only", &CloneOptions{RemoteCreateCallback: noop}, false},
+		{"mirror and callback", &CloneOptions{Mirror: true, RemoteCreateCallback: noop}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMirrorOptions(tt.options)
+			if tt.wantErr && err == nil {
+				t.Fatal("validateMirrorOptions: expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validateMirrorOptions: unexpected error: %v", err)
+			}
+		})
+	}
+}
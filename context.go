@@ -0,0 +1,67 @@
+package git
+
+import "context"
+
+// wrapFetchOptionsContext returns a shallow copy of opts (or a fresh
+// FetchOptions if opts is nil) whose progress callbacks abort the transfer
+// with ErrorCodeUser as soon as ctx is done. Wrapping happens even when the
+// caller registered no progress callbacks of their own, since those are the
+// only callbacks libgit2 gives us an opportunity to bail out from mid-fetch.
+func wrapFetchOptionsContext(ctx context.Context, opts *FetchOptions) *FetchOptions {
+	wrapped := FetchOptions{}
+	if opts != nil {
+		wrapped = *opts
+	}
+
+	userTransferProgress := wrapped.RemoteCallbacks.TransferProgressCallback
+	wrapped.RemoteCallbacks.TransferProgressCallback = func(stats TransferProgress) ErrorCode {
+		if ctx.Err() != nil {
+			return ErrorCodeUser
+		}
+		if userTransferProgress != nil {
+			return userTransferProgress(stats)
+		}
+		return ErrorCodeOK
+	}
+
+	userSidebandProgress := wrapped.RemoteCallbacks.SidebandProgressCallback
+	wrapped.RemoteCallbacks.SidebandProgressCallback = func(str string) ErrorCode {
+		if ctx.Err() != nil {
+			return ErrorCodeUser
+		}
+		if userSidebandProgress != nil {
+			return userSidebandProgress(str)
+		}
+		return ErrorCodeOK
+	}
+
+	return &wrapped
+}
+
+// CloneContext clones a repository the same way as Clone, but aborts the
+// transfer and returns ctx.Err() as soon as ctx is done, instead of
+// whatever error libgit2 surfaces for the aborted operation.
+func CloneContext(ctx context.Context, url string, path string, options *CloneOptions) (*Repository, error) {
+	wrapped := CloneOptions{}
+	if options != nil {
+		wrapped = *options
+	}
+	wrapped.FetchOptions = wrapFetchOptionsContext(ctx, wrapped.FetchOptions)
+
+	repo, err := Clone(url, path, &wrapped)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return repo, err
+}
+
+// FetchContext fetches from the remote the same way as Fetch, but aborts
+// the transfer and returns ctx.Err() as soon as ctx is done, instead of
+// whatever error libgit2 surfaces for the aborted operation.
+func (o *Remote) FetchContext(ctx context.Context, refspecs []string, opts *FetchOptions, msg string) error {
+	err := o.Fetch(refspecs, wrapFetchOptionsContext(ctx, opts), msg)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package git
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWrapFetchOptionsContextAbortsWhenDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	wrapped := wrapFetchOptionsContext(ctx, nil)
+
+	if got := wrapped.RemoteCallbacks.TransferProgressCallback(TransferProgress{}); got != ErrorCodeUser {
+		t.Fatalf("TransferProgressCallback = %v, want ErrorCodeUser", got)
+	}
+	if got := wrapped.RemoteCallbacks.SidebandProgressCallback(""); got != ErrorCodeUser {
+		t.Fatalf("SidebandProgressCallback = %v, want ErrorCodeUser", got)
+	}
+}
+
+func TestWrapFetchOptionsContextDelegatesToUserCallback(t *testing.T) {
+	ctx := context.Background()
+
+	var gotStats TransferProgress
+	opts := &FetchOptions{
+		RemoteCallbacks: RemoteCallbacks{
+			TransferProgressCallback: func(stats TransferProgress) ErrorCode {
+				gotStats = stats
+				return ErrorCodeOK
+			},
+		},
+	}
+
+	wrapped := wrapFetchOptionsContext(ctx, opts)
+
+	want := TransferProgress{TotalObjects: 42}
+	if got := wrapped.RemoteCallbacks.TransferProgressCallback(want); got != ErrorCodeOK {
+		t.Fatalf("TransferProgressCallback = %v, want ErrorCodeOK", got)
+	}
+	if gotStats != want {
+		t.Fatalf("user callback saw %+v, want %+v", gotStats, want)
+	}
+}
+
+func TestWrapFetchOptionsContextDefaultsWithoutUserCallback(t *testing.T) {
+	wrapped := wrapFetchOptionsContext(context.Background(), nil)
+
+	if got := wrapped.RemoteCallbacks.SidebandProgressCallback("progress"); got != ErrorCodeOK {
+		t.Fatalf("SidebandProgressCallback = %v, want ErrorCodeOK", got)
+	}
+}
@@ -0,0 +1,41 @@
+package git
+
+/*
+#include <git2.h>
+*/
+import "C"
+
+// FetchOptions controls how a fetch against a remote is performed, either on
+// its own via Remote.Fetch or as part of Clone.
+type FetchOptions struct {
+	RemoteCallbacks RemoteCallbacks
+
+	// Depth limits the fetch to the given number of commits from the tip of
+	// each remote branch, producing a shallow clone/fetch. A Depth of 0 (the
+	// default) fetches the complete history.
+	//
+	// Deepening an existing shallow repository, or converting one to a
+	// complete repository ("unshallow"), isn't wired up yet; Depth only
+	// applies to the initial fetch/clone.
+	Depth int
+}
+
+func populateFetchOptions(ptr *C.git_fetch_options, opts *FetchOptions, errorTarget *error) *C.git_fetch_options {
+	C.git_fetch_options_init(ptr, C.GIT_FETCH_OPTIONS_VERSION)
+	if opts == nil {
+		return ptr
+	}
+
+	ptr.depth = C.int(opts.Depth)
+	populateRemoteCallbacks(&ptr.callbacks, &opts.RemoteCallbacks, errorTarget)
+
+	return ptr
+}
+
+func freeFetchOptions(ptr *C.git_fetch_options) {
+	if ptr == nil || ptr.callbacks.payload == nil {
+		return
+	}
+
+	pointerHandles.Untrack(ptr.callbacks.payload)
+}
@@ -0,0 +1,51 @@
+package git
+
+/*
+#include <git2.h>
+*/
+import "C"
+import "unsafe"
+
+const mirrorFetchspec = "+refs/*:refs/*"
+
+// mirrorConfigKey returns the config key that marks remote name as a
+// mirror, e.g. "remote.origin.mirror".
+func mirrorConfigKey(name string) string {
+	return "remote." + name + ".mirror"
+}
+
+// mirrorRemoteCreateCallback implements CloneOptions.Mirror: it creates the
+// clone's remote with a mirroring refspec and marks it as a mirror in the
+// repository's config, matching `git clone --mirror` semantics.
+func mirrorRemoteCreateCallback(repo *Repository, name, url string) (*Remote, ErrorCode) {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+
+	curl := C.CString(url)
+	defer C.free(unsafe.Pointer(curl))
+
+	cfetchspec := C.CString(mirrorFetchspec)
+	defer C.free(unsafe.Pointer(cfetchspec))
+
+	var ptr *C.git_remote
+	ret := C.git_remote_create_with_fetchspec(&ptr, repo.ptr, cname, curl, cfetchspec)
+	if ret < 0 {
+		return nil, ErrorCode(ret)
+	}
+	remote := newRemoteFromC(ptr)
+
+	cfg, err := repo.Config()
+	if err != nil {
+		// The remote was created successfully; failing to flip the mirror
+		// bit in the config isn't worth aborting the clone over.
+		return remote, ErrorCodeOK
+	}
+	defer cfg.Free()
+
+	// Same rationale as the repo.Config() error above: the remote already
+	// exists, so a failure to flip the mirror bit isn't worth aborting the
+	// clone over.
+	_ = cfg.SetBool(mirrorConfigKey(name), true)
+
+	return remote, ErrorCodeOK
+}
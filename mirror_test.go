@@ -0,0 +1,16 @@
+package git
+
+import "testing"
+
+func TestMirrorConfigKey(t *testing.T) {
+	tests := map[string]string{
+		"origin":       "remote.origin.mirror",
+		"upstream-mir": "remote.upstream-mir.mirror",
+	}
+
+	for name, want := range tests {
+		if got := mirrorConfigKey(name); got != want {
+			t.Errorf("mirrorConfigKey(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
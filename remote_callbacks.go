@@ -0,0 +1,143 @@
+package git
+
+/*
+#include <git2.h>
+
+extern void _go_git_populate_fetch_callbacks(git_remote_callbacks *callbacks);
+*/
+import "C"
+import (
+	"errors"
+	"unsafe"
+)
+
+// TransferProgress describes the progress of a fetch, mirroring
+// git_indexer_progress.
+type TransferProgress struct {
+	TotalObjects    uint
+	IndexedObjects  uint
+	ReceivedObjects uint
+	LocalObjects    uint
+	TotalDeltas     uint
+	IndexedDeltas   uint
+	ReceivedBytes   uint64
+}
+
+func newTransferProgressFromC(stats *C.git_indexer_progress) TransferProgress {
+	return TransferProgress{
+		TotalObjects:    uint(stats.total_objects),
+		IndexedObjects:  uint(stats.indexed_objects),
+		ReceivedObjects: uint(stats.received_objects),
+		LocalObjects:    uint(stats.local_objects),
+		TotalDeltas:     uint(stats.total_deltas),
+		IndexedDeltas:   uint(stats.indexed_deltas),
+		ReceivedBytes:   uint64(stats.received_bytes),
+	}
+}
+
+// TransferProgressCallback is invoked periodically as objects are
+// downloaded. Returning a negative ErrorCode aborts the transfer.
+type TransferProgressCallback func(stats TransferProgress) ErrorCode
+
+// SidebandProgressCallback is invoked with server-sent progress text, e.g.
+// "Counting objects: 10, done.". Returning a negative ErrorCode aborts the
+// transfer.
+type SidebandProgressCallback func(str string) ErrorCode
+
+// CertificateCheckCallback is invoked when libgit2 cannot otherwise verify
+// the certificate presented by the remote (an X.509 certificate over HTTPS,
+// or a host key over SSH), e.g. because it is self-signed or because libgit2
+// was not built with a TLS/SSH backend that validates it. valid reports
+// libgit2's own verification result, and hostname is the host being
+// connected to. Returning a negative ErrorCode aborts the connection; ErrorCodeOK
+// accepts the certificate.
+type CertificateCheckCallback func(cert *Certificate, valid bool, hostname string) ErrorCode
+
+// RemoteCallbacks holds the callbacks that libgit2 invokes while a fetch
+// (or a clone, which performs a fetch internally) is in progress.
+type RemoteCallbacks struct {
+	SidebandProgressCallback SidebandProgressCallback
+	TransferProgressCallback TransferProgressCallback
+	CertificateCheckCallback CertificateCheckCallback
+}
+
+type remoteCallbacksData struct {
+	callbacks   *RemoteCallbacks
+	errorTarget *error
+}
+
+// normalizeCallbackAbort takes a user callback's ErrorCode result and, if it
+// indicates failure, records the underlying error in errorTarget and
+// reports ErrorCodeUser to libgit2 — the single abort code Clone's
+// `ret == C.int(ErrorCodeUser) && err != nil` check recognizes, mirroring
+// remoteCreateCallback. Non-negative results pass through unchanged.
+func normalizeCallbackAbort(ret ErrorCode, errorTarget *error) ErrorCode {
+	if ret < 0 {
+		*errorTarget = errors.New(ret.String())
+		return ErrorCodeUser
+	}
+	return ret
+}
+
+// populateRemoteCallbacks wires up callbacks to the given git_remote_callbacks
+// struct and returns the tracked payload handle, or nil if callbacks has
+// nothing to wire up.
+func populateRemoteCallbacks(ptr *C.git_remote_callbacks, callbacks *RemoteCallbacks, errorTarget *error) unsafe.Pointer {
+	if callbacks == nil {
+		return nil
+	}
+	if callbacks.TransferProgressCallback == nil && callbacks.SidebandProgressCallback == nil && callbacks.CertificateCheckCallback == nil {
+		return nil
+	}
+
+	data := &remoteCallbacksData{
+		callbacks:   callbacks,
+		errorTarget: errorTarget,
+	}
+
+	C._go_git_populate_fetch_callbacks(ptr)
+	payload := pointerHandles.Track(data)
+	ptr.payload = payload
+	return payload
+}
+
+//export transferProgressCallback
+func transferProgressCallback(stats *C.git_indexer_progress, payload unsafe.Pointer) C.int {
+	data, ok := pointerHandles.Get(payload).(*remoteCallbacksData)
+	if !ok || data.callbacks.TransferProgressCallback == nil {
+		return C.int(ErrorCodeOK)
+	}
+
+	ret := data.callbacks.TransferProgressCallback(newTransferProgressFromC(stats))
+	return C.int(normalizeCallbackAbort(ret, data.errorTarget))
+}
+
+//export sidebandProgressCallback
+func sidebandProgressCallback(str *C.char, length C.int, payload unsafe.Pointer) C.int {
+	data, ok := pointerHandles.Get(payload).(*remoteCallbacksData)
+	if !ok || data.callbacks.SidebandProgressCallback == nil {
+		return C.int(ErrorCodeOK)
+	}
+
+	ret := data.callbacks.SidebandProgressCallback(C.GoStringN(str, length))
+	return C.int(normalizeCallbackAbort(ret, data.errorTarget))
+}
+
+//export certificateCheckCallback
+func certificateCheckCallback(ccert *C.git_cert, valid C.int, chostname *C.char, payload unsafe.Pointer) C.int {
+	data, ok := pointerHandles.Get(payload).(*remoteCallbacksData)
+	if !ok || data.callbacks.CertificateCheckCallback == nil {
+		// No user callback: keep libgit2's own verification result rather
+		// than accepting every certificate.
+		if valid != 0 {
+			return C.int(ErrorCodeOK)
+		}
+		return C.int(ErrorCodeCertificate)
+	}
+
+	cert := newCertificateFromC(ccert)
+	hostname := C.GoString(chostname)
+
+	ret := data.callbacks.CertificateCheckCallback(cert, valid != 0, hostname)
+	return C.int(normalizeCallbackAbort(ret, data.errorTarget))
+}
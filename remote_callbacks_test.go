@@ -0,0 +1,31 @@
+package git
+
+import "testing"
+
+func TestNormalizeCallbackAbortPassesThroughSuccess(t *testing.T) {
+	var errorTarget error
+
+	got := normalizeCallbackAbort(ErrorCodeOK, &errorTarget)
+	if got != ErrorCodeOK {
+		t.Fatalf("normalizeCallbackAbort = %v, want ErrorCodeOK", got)
+	}
+	if errorTarget != nil {
+		t.Fatalf("errorTarget = %v, want nil", errorTarget)
+	}
+}
+
+func TestNormalizeCallbackAbortNormalizesNegativeCodes(t *testing.T) {
+	tests := []ErrorCode{ErrorCodeUser, ErrorCodeCertificate, ErrorCodeAuth, -1}
+
+	for _, ret := range tests {
+		var errorTarget error
+
+		got := normalizeCallbackAbort(ret, &errorTarget)
+		if got != ErrorCodeUser {
+			t.Errorf("normalizeCallbackAbort(%v) = %v, want ErrorCodeUser", ret, got)
+		}
+		if errorTarget == nil {
+			t.Errorf("normalizeCallbackAbort(%v): errorTarget left nil, want the real error preserved", ret)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Package repository abstracts the git operations git2go performs behind an
+// interface, so that consumers can substitute a different backend (e.g. a
+// pure-Go implementation for tests or WASM builds) without changing call
+// sites written against this package.
+//
+// Deviation from the original ask: rather than having the top-level
+// git.Clone delegate into this package's default RepositoryClient, the
+// dependency runs the other way — libgit2Client.Clone delegates to
+// git.CloneContext. git.Clone staying authoritative avoids an import cycle
+// (this package already imports git for its public types), at the cost of
+// the two entry points only being kept in sync by convention rather than by
+// construction.
+package repository
+
+import (
+	"context"
+
+	git "github.com/suhaibmujahid/git2go"
+)
+
+// RepositoryClient is implemented by every backend capable of performing the
+// handful of repository operations most callers need. The default
+// implementation, returned by NewClient, is backed by git2go's cgo bindings.
+type RepositoryClient interface {
+	Clone(ctx context.Context, url, path string, opts *git.CloneOptions) (*git.Repository, error)
+	Fetch(ctx context.Context, repo *git.Repository, remote string, opts *git.FetchOptions) error
+	Push(ctx context.Context, repo *git.Repository, remote string, refspecs []string) error
+	Checkout(ctx context.Context, repo *git.Repository, opts *git.CheckoutOpts) error
+}
+
+// ClientOptions carries the defaults a Client applies to its Clone and Fetch
+// calls, so callers don't have to plumb auth/transport settings through
+// every call individually. Push and Checkout do not use them.
+type ClientOptions struct {
+	// FetchOptions, typically holding auth/transport callbacks, is used for
+	// any Clone/Fetch call that doesn't supply its own.
+	FetchOptions *git.FetchOptions
+}
+
+// NewClient returns the default RepositoryClient, backed by git2go's cgo
+// bindings.
+func NewClient(opts ClientOptions) RepositoryClient {
+	return &libgit2Client{opts: opts}
+}
+
+// libgit2Client is the cgo-backed RepositoryClient returned by NewClient. It
+// delegates to the package-level Clone/CloneContext so the two entry points
+// stay in lockstep.
+type libgit2Client struct {
+	opts ClientOptions
+}
+
+func (c *libgit2Client) Clone(ctx context.Context, url, path string, opts *git.CloneOptions) (*git.Repository, error) {
+	return git.CloneContext(ctx, url, path, c.withDefaultFetchOptions(opts))
+}
+
+func (c *libgit2Client) Fetch(ctx context.Context, repo *git.Repository, remote string, opts *git.FetchOptions) error {
+	r, err := repo.Remotes.Lookup(remote)
+	if err != nil {
+		return err
+	}
+	if opts == nil {
+		opts = c.opts.FetchOptions
+	}
+	return r.FetchContext(ctx, nil, opts, "")
+}
+
+func (c *libgit2Client) Push(ctx context.Context, repo *git.Repository, remote string, refspecs []string) error {
+	return runWithContext(ctx, func() error {
+		r, err := repo.Remotes.Lookup(remote)
+		if err != nil {
+			return err
+		}
+		return r.Push(refspecs, nil)
+	})
+}
+
+func (c *libgit2Client) Checkout(ctx context.Context, repo *git.Repository, opts *git.CheckoutOpts) error {
+	return runWithContext(ctx, func() error {
+		return repo.CheckoutHead(opts)
+	})
+}
+
+func (c *libgit2Client) withDefaultFetchOptions(opts *git.CloneOptions) *git.CloneOptions {
+	cp := git.CloneOptions{}
+	if opts != nil {
+		cp = *opts
+	}
+	if cp.FetchOptions == nil {
+		cp.FetchOptions = c.opts.FetchOptions
+	}
+	return &cp
+}
+
+// runWithContext runs fn in the background and returns its result, unless
+// ctx is done first, in which case it returns ctx.Err() without waiting.
+// Unlike Clone/Fetch, neither git_push nor git_checkout_head gives us a
+// callback to hook cancellation into, so fn keeps running to completion in
+// the background even after ctx is done; this only bounds how long the
+// caller waits for it.
+func runWithContext(ctx context.Context, fn func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- fn() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	git "github.com/suhaibmujahid/git2go"
+)
+
+func TestWithDefaultFetchOptionsFillsInClientDefault(t *testing.T) {
+	def := &git.FetchOptions{Depth: 1}
+	c := &libgit2Client{opts: ClientOptions{FetchOptions: def}}
+
+	got := c.withDefaultFetchOptions(nil)
+	if got.FetchOptions != def {
+		t.Fatalf("FetchOptions = %p, want client default %p", got.FetchOptions, def)
+	}
+}
+
+func TestWithDefaultFetchOptionsKeepsCallerOptions(t *testing.T) {
+	def := &git.FetchOptions{Depth: 1}
+	own := &git.FetchOptions{Depth: 2}
+	c := &libgit2Client{opts: ClientOptions{FetchOptions: def}}
+
+	got := c.withDefaultFetchOptions(&git.CloneOptions{FetchOptions: own})
+	if got.FetchOptions != own {
+		t.Fatalf("FetchOptions = %p, want caller-supplied %p", got.FetchOptions, own)
+	}
+}
+
+func TestWithDefaultFetchOptionsDoesNotMutateSharedTemplate(t *testing.T) {
+	template := &git.CloneOptions{}
+
+	first := &libgit2Client{opts: ClientOptions{FetchOptions: &git.FetchOptions{Depth: 1}}}
+	second := &libgit2Client{opts: ClientOptions{FetchOptions: &git.FetchOptions{Depth: 2}}}
+
+	got := first.withDefaultFetchOptions(template)
+	if got.FetchOptions != first.opts.FetchOptions {
+		t.Fatalf("first client's FetchOptions = %p, want %p", got.FetchOptions, first.opts.FetchOptions)
+	}
+	if template.FetchOptions != nil {
+		t.Fatalf("shared template.FetchOptions = %p, want it left untouched (nil)", template.FetchOptions)
+	}
+
+	got = second.withDefaultFetchOptions(template)
+	if got.FetchOptions != second.opts.FetchOptions {
+		t.Fatalf("second client's FetchOptions = %p, want %p; first call's default leaked into the shared template", got.FetchOptions, second.opts.FetchOptions)
+	}
+}
+
+func TestRunWithContextReturnsCtxErrWhenDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	block := make(chan struct{})
+	defer close(block)
+
+	if err := runWithContext(ctx, func() error {
+		<-block
+		return nil
+	}); err != context.Canceled {
+		t.Fatalf("runWithContext = %v, want context.Canceled", err)
+	}
+}
+
+func TestRunWithContextReturnsFnResult(t *testing.T) {
+	want := errors.New("boom")
+
+	if err := runWithContext(context.Background(), func() error {
+		return want
+	}); err != want {
+		t.Fatalf("runWithContext = %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,55 @@
+package git
+
+/*
+#include <git2.h>
+*/
+import "C"
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// IsShallow returns whether the repository is a shallow clone, i.e. one
+// created (or fetched into) with a Depth-limited CloneOptions/FetchOptions.
+func (r *Repository) IsShallow() (bool, error) {
+	ret := C.git_repository_is_shallow(r.ptr)
+	if ret < 0 {
+		return false, MakeGitError(ret)
+	}
+	return ret == 1, nil
+}
+
+// ShallowRoots returns the boundary commits of a shallow repository, i.e.
+// the commits recorded in .git/shallow whose parents were not fetched. It
+// returns an empty slice for a repository that is not shallow.
+func (r *Repository) ShallowRoots() ([]*Oid, error) {
+	data, err := os.ReadFile(filepath.Join(r.Path(), "shallow"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return parseShallowFile(data)
+}
+
+// parseShallowFile parses the contents of a .git/shallow file: one commit
+// OID per line, as written by git_clone/git_fetch for a shallow repository.
+func parseShallowFile(data []byte) ([]*Oid, error) {
+	var roots []*Oid
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		oid, err := NewOid(line)
+		if err != nil {
+			return nil, err
+		}
+		roots = append(roots, oid)
+	}
+
+	return roots, nil
+}
@@ -0,0 +1,44 @@
+package git
+
+import "testing"
+
+func TestParseShallowFile(t *testing.T) {
+	const oid1 = "1111111111111111111111111111111111111111"
+	const oid2 = "2222222222222222222222222222222222222222"
+
+	roots, err := parseShallowFile([]byte(oid1 + "\n" + oid2 + "\n"))
+	if err != nil {
+		t.Fatalf("parseShallowFile returned error: %v", err)
+	}
+	if len(roots) != 2 || roots[0].String() != oid1 || roots[1].String() != oid2 {
+		t.Fatalf("parseShallowFile = %v, want [%s %s]", roots, oid1, oid2)
+	}
+}
+
+func TestParseShallowFileIgnoresBlankLines(t *testing.T) {
+	const oid1 = "1111111111111111111111111111111111111111"
+
+	roots, err := parseShallowFile([]byte("\n" + oid1 + "\n\n"))
+	if err != nil {
+		t.Fatalf("parseShallowFile returned error: %v", err)
+	}
+	if len(roots) != 1 || roots[0].String() != oid1 {
+		t.Fatalf("parseShallowFile = %v, want [%s]", roots, oid1)
+	}
+}
+
+func TestParseShallowFileEmpty(t *testing.T) {
+	roots, err := parseShallowFile(nil)
+	if err != nil {
+		t.Fatalf("parseShallowFile returned error: %v", err)
+	}
+	if len(roots) != 0 {
+		t.Fatalf("parseShallowFile = %v, want none", roots)
+	}
+}
+
+func TestParseShallowFileRejectsMalformedOid(t *testing.T) {
+	if _, err := parseShallowFile([]byte("not-an-oid\n")); err == nil {
+		t.Fatal("parseShallowFile of a malformed OID: expected error, got nil")
+	}
+}